@@ -0,0 +1,246 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+)
+
+// BaseBlockKeeper keeps the most recent blocks of a single canonical chain in a
+// fixed-capacity ring buffer, indexed by hash for O(1) lookups.
+type BaseBlockKeeper struct {
+	blocks    []types.Block
+	hashIndex map[string]int
+	cap       int
+
+	finalized   *types.Block
+	finalizedCh []chan types.Block
+
+	bloom *countingBloomFilter
+
+	mu sync.Mutex
+}
+
+// NewBaseBlockKeeper returns a BaseBlockKeeper that retains at most cap blocks.
+func NewBaseBlockKeeper(cap int) *BaseBlockKeeper {
+	return &BaseBlockKeeper{
+		blocks:    make([]types.Block, 0, cap),
+		hashIndex: make(map[string]int, cap),
+		cap:       cap,
+	}
+}
+
+// NewBaseBlockKeeperWithBloom returns a BaseBlockKeeper fronted by a counting
+// bloom filter, so that Exists can reject hashes it has never seen without
+// touching the underlying map. bloomM is the number of counter cells and bloomK the
+// number of hash functions; pass 0 for either to size the filter automatically
+// for a false-positive rate under ~1% at cap entries.
+func NewBaseBlockKeeperWithBloom(cap, bloomM, bloomK uint) *BaseBlockKeeper {
+	if bloomM == 0 || bloomK == 0 {
+		bloomM, bloomK = bloomSizeFor(cap)
+	}
+
+	k := NewBaseBlockKeeper(int(cap))
+	k.bloom = newCountingBloomFilter(bloomM, bloomK)
+
+	return k
+}
+
+// Init resets the keeper to an empty state.
+func (k *BaseBlockKeeper) Init() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.blocks = k.blocks[:0]
+	k.hashIndex = make(map[string]int, k.cap)
+	k.finalized = nil
+
+	if k.bloom != nil {
+		k.bloom = newCountingBloomFilter(k.bloom.m, k.bloom.k)
+	}
+
+	return nil
+}
+
+// Len returns the number of blocks currently held.
+func (k *BaseBlockKeeper) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return len(k.blocks)
+}
+
+// Cap returns the maximum number of blocks the keeper can hold.
+func (k *BaseBlockKeeper) Cap() int {
+	return k.cap
+}
+
+// Add inserts a new block into the keeper, evicting the oldest block if full.
+func (k *BaseBlockKeeper) Add(block types.Block) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.hashIndex[block.Hash]; ok {
+		return errors.ErrAlreadyExists
+	}
+
+	if len(k.blocks) >= k.cap {
+		k.evictOldest()
+	}
+
+	k.blocks = append(k.blocks, block)
+	k.hashIndex[block.Hash] = len(k.blocks) - 1
+
+	if k.bloom != nil {
+		k.bloom.Add(block.Hash)
+	}
+
+	return nil
+}
+
+func (k *BaseBlockKeeper) evictOldest() {
+	oldest := k.blocks[0]
+	k.blocks = k.blocks[1:]
+	delete(k.hashIndex, oldest.Hash)
+
+	for hash, idx := range k.hashIndex {
+		k.hashIndex[hash] = idx - 1
+	}
+
+	if k.bloom != nil {
+		k.bloom.Remove(oldest.Hash)
+	}
+}
+
+// Exists reports whether a block with the given hash is known to the keeper. If
+// the keeper was constructed with a bloom filter, a negative bloom check short
+// circuits the map lookup; a positive hit still falls through to the
+// authoritative check.
+func (k *BaseBlockKeeper) Exists(hash string) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.bloom != nil && !k.bloom.MayContain(hash) {
+		return false, nil
+	}
+
+	_, ok := k.hashIndex[hash]
+
+	return ok, nil
+}
+
+// Get returns the block with the given hash.
+func (k *BaseBlockKeeper) Get(hash string) (types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	idx, ok := k.hashIndex[hash]
+	if !ok {
+		return types.Block{}, errors.ErrNotFound
+	}
+
+	return k.blocks[idx], nil
+}
+
+// Head returns the current canonical tip, i.e. the most recently added block.
+func (k *BaseBlockKeeper) Head() (types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if len(k.blocks) == 0 {
+		return types.Block{}, errors.ErrNotFound
+	}
+
+	return k.blocks[len(k.blocks)-1], nil
+}
+
+// IsReorg reports whether block does not extend the current head, i.e. its parent
+// hash does not match the head's hash. It returns ErrReorgBelowFinalized if
+// accepting block would rewind the chain past the finalized block.
+func (k *BaseBlockKeeper) IsReorg(block types.Block) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if len(k.blocks) == 0 {
+		return false, nil
+	}
+
+	head := k.blocks[len(k.blocks)-1]
+	isReorg := block.ParentHash != head.Hash
+
+	if isReorg && k.finalized != nil && block.Number.Cmp(k.finalized.Number) <= 0 {
+		return false, errors.ErrReorgBelowFinalized
+	}
+
+	return isReorg, nil
+}
+
+// MarkFinalized designates the block with the given hash as finalized and
+// notifies any subscribers.
+func (k *BaseBlockKeeper) MarkFinalized(hash string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	idx, ok := k.hashIndex[hash]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	block := k.blocks[idx]
+	k.finalized = &block
+
+	for _, ch := range k.finalizedCh {
+		select {
+		case ch <- block:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Finalized returns the most recently finalized block.
+func (k *BaseBlockKeeper) Finalized() (types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.finalized == nil {
+		return types.Block{}, errors.ErrNotFound
+	}
+
+	return *k.finalized, nil
+}
+
+// SubscribeFinalized returns a channel that receives every block marked
+// finalized from this point on.
+func (k *BaseBlockKeeper) SubscribeFinalized() <-chan types.Block {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	ch := make(chan types.Block, 1)
+	k.finalizedCh = append(k.finalizedCh, ch)
+
+	return ch
+}
+
+// GetRecentBlocks returns up to n of the most recently added blocks, newest first.
+func (k *BaseBlockKeeper) GetRecentBlocks(n int) ([]types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if n <= 0 {
+		return nil, errors.ErrInvalidArgument
+	}
+
+	if n > len(k.blocks) {
+		n = len(k.blocks)
+	}
+
+	blocks := make([]types.Block, n)
+	for i := 0; i < n; i++ {
+		blocks[i] = k.blocks[len(k.blocks)-1-i]
+	}
+
+	return blocks, nil
+}
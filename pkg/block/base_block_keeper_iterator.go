@@ -0,0 +1,73 @@
+package block
+
+import (
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+)
+
+// baseBlockKeeperAncestorIterator walks a BaseBlockKeeper's chain backwards by
+// following ParentHash through the live hashIndex on every step, rather than a
+// slice index fixed at construction time. Add's eviction of the oldest block
+// shifts every live index in the blocks slice, so a cached index would silently
+// resolve to the wrong block after a concurrent Add; re-resolving by hash
+// instead makes an evicted ancestor stop the iterator rather than corrupt it.
+type baseBlockKeeperAncestorIterator struct {
+	k        *BaseBlockKeeper
+	nextHash string
+	done     bool
+	block    types.Block
+	err      error
+}
+
+// AncestorsOf returns an iterator over the ancestors of the block with the given
+// hash, walking back through the keeper's single canonical chain.
+func (k *BaseBlockKeeper) AncestorsOf(hash string) types.BlockIterator {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	idx, ok := k.hashIndex[hash]
+	if !ok {
+		return &baseBlockKeeperAncestorIterator{err: errors.ErrNotFound}
+	}
+
+	return &baseBlockKeeperAncestorIterator{k: k, nextHash: k.blocks[idx].ParentHash}
+}
+
+// Next advances the iterator and reports whether a block is available. It
+// stops, rather than returning a stale block, if the next ancestor's hash has
+// since been evicted from the keeper.
+func (it *baseBlockKeeperAncestorIterator) Next() bool {
+	if it.err != nil || it.k == nil || it.done {
+		return false
+	}
+
+	it.k.mu.Lock()
+	defer it.k.mu.Unlock()
+
+	idx, ok := it.k.hashIndex[it.nextHash]
+	if !ok {
+		it.done = true
+
+		return false
+	}
+
+	it.block = it.k.blocks[idx]
+	it.nextHash = it.block.ParentHash
+
+	return true
+}
+
+// Block returns the block at the iterator's current position.
+func (it *baseBlockKeeperAncestorIterator) Block() *types.Block {
+	return &it.block
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *baseBlockKeeperAncestorIterator) Err() error {
+	return it.err
+}
+
+// Close is a no-op: the iterator holds no resources beyond the keeper itself.
+func (it *baseBlockKeeperAncestorIterator) Close() error {
+	return nil
+}
@@ -0,0 +1,98 @@
+package block
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+)
+
+// baseBlockKeeperSnapshotVersion is the current on-disk layout of a
+// BaseBlockKeeper snapshot. Bump it whenever the payload shape changes, e.g. when
+// the fork-tree keeper grows its own snapshot format, so Restore can reject
+// snapshots it no longer understands instead of silently corrupting state.
+const baseBlockKeeperSnapshotVersion byte = 1
+
+type baseBlockKeeperSnapshotPayload struct {
+	Cap          int
+	Blocks       []types.Block
+	Finalized    types.Block
+	HasFinalized bool
+}
+
+// Snapshot encodes the keeper's full state so it can be restored after a restart.
+func (k *BaseBlockKeeper) Snapshot() ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	payload := baseBlockKeeperSnapshotPayload{
+		Cap:    k.cap,
+		Blocks: append([]types.Block(nil), k.blocks...),
+	}
+
+	if k.finalized != nil {
+		payload.Finalized = *k.finalized
+		payload.HasFinalized = true
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteByte(baseBlockKeeperSnapshotVersion)
+
+	if err := gob.NewEncoder(&buf).Encode(payload); err != nil {
+		return nil, fmt.Errorf("encode base block keeper snapshot: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Restore replaces the keeper's state with the one encoded in data by Snapshot,
+// rebuilding the hash index from the restored blocks.
+func (k *BaseBlockKeeper) Restore(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("restore base block keeper snapshot: empty payload")
+	}
+
+	version, body := data[0], data[1:]
+	if version != baseBlockKeeperSnapshotVersion {
+		return fmt.Errorf("restore base block keeper snapshot: unsupported version %d", version)
+	}
+
+	var payload baseBlockKeeperSnapshotPayload
+	if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&payload); err != nil {
+		return fmt.Errorf("decode base block keeper snapshot: %w", err)
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if payload.Cap != k.cap {
+		return fmt.Errorf("restore base block keeper snapshot: cap %d does not match keeper cap %d",
+			payload.Cap, k.cap)
+	}
+
+	k.blocks = payload.Blocks
+	k.hashIndex = make(map[string]int, k.cap)
+
+	if k.bloom != nil {
+		k.bloom = newCountingBloomFilter(k.bloom.m, k.bloom.k)
+	}
+
+	for i, block := range k.blocks {
+		k.hashIndex[block.Hash] = i
+
+		if k.bloom != nil {
+			k.bloom.Add(block.Hash)
+		}
+	}
+
+	if payload.HasFinalized {
+		finalized := payload.Finalized
+		k.finalized = &finalized
+	} else {
+		k.finalized = nil
+	}
+
+	return nil
+}
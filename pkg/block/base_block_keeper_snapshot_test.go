@@ -0,0 +1,57 @@
+package block
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseBlockKeeperSnapshotRestore(t *testing.T) {
+	keeper := NewBaseBlockKeeper(4)
+	for _, b := range sampleBlocks {
+		require.NoError(t, keeper.Add(b))
+	}
+	require.NoError(t, keeper.MarkFinalized(sampleBlocks[0].Hash))
+
+	data, err := keeper.Snapshot()
+	require.NoError(t, err)
+
+	// Mutate the keeper after taking the snapshot to prove Restore overwrites
+	// this state rather than merging with it.
+	require.NoError(t, keeper.Add(types.Block{
+		Number:     big.NewInt(35338115),
+		Hash:       "0x29736b68f357f61d0ae3d8b78762949a0b2da1d99b0f4a9be56edd28e7839643",
+		ParentHash: sampleBlocks[2].Hash,
+	}))
+
+	restored := NewBaseBlockKeeper(4)
+	require.NoError(t, restored.Restore(data))
+
+	assert.Equal(t, 3, restored.Len())
+
+	head, err := restored.Head()
+	require.NoError(t, err)
+	assert.Equal(t, sampleBlocks[2], head)
+
+	finalized, err := restored.Finalized()
+	require.NoError(t, err)
+	assert.Equal(t, sampleBlocks[0], finalized)
+
+	exists, err := restored.Exists(sampleBlocks[1].Hash)
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestBaseBlockKeeperRestoreRejectsMismatchedCap(t *testing.T) {
+	keeper := NewBaseBlockKeeper(4)
+	require.NoError(t, keeper.Add(sampleBlocks[0]))
+
+	data, err := keeper.Snapshot()
+	require.NoError(t, err)
+
+	other := NewBaseBlockKeeper(8)
+	assert.Error(t, other.Restore(data))
+}
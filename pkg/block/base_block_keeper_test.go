@@ -6,6 +6,8 @@ import (
 
 	"github.com/KyberNetwork/evmlistener/pkg/errors"
 	"github.com/KyberNetwork/evmlistener/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -241,6 +243,124 @@ func (ts *BaseBlockKeeperTestSuite) TestGetRecentBlocks() {
 	}
 }
 
+func (ts *BaseBlockKeeperTestSuite) TestMarkFinalized() {
+	err := ts.keeper.MarkFinalized("")
+	ts.Assert().ErrorIs(err, errors.ErrNotFound)
+
+	_, err = ts.keeper.Finalized()
+	ts.Assert().ErrorIs(err, errors.ErrNotFound)
+
+	sub := ts.keeper.SubscribeFinalized()
+
+	err = ts.keeper.MarkFinalized(sampleBlocks[1].Hash)
+	if ts.Assert().NoError(err) {
+		block, err := ts.keeper.Finalized()
+		if ts.Assert().NoError(err) {
+			ts.Assert().Equal(sampleBlocks[1], block)
+		}
+
+		ts.Assert().Equal(sampleBlocks[1], <-sub)
+	}
+}
+
+func (ts *BaseBlockKeeperTestSuite) TestIsReorgBelowFinalized() {
+	ts.Require().NoError(ts.keeper.MarkFinalized(sampleBlocks[2].Hash))
+
+	block := types.Block{
+		Number:     big.NewInt(35338114),
+		Hash:       "0x29736b68f357f61d0ae3d8b78762949a0b2da1d99b0f4a9be56edd28e7839643",
+		ParentHash: sampleBlocks[1].Hash,
+	}
+
+	_, err := ts.keeper.IsReorg(block)
+	ts.Assert().ErrorIs(err, errors.ErrReorgBelowFinalized)
+}
+
+func (ts *BaseBlockKeeperTestSuite) TestAncestorsOf() {
+	it := ts.keeper.AncestorsOf(sampleBlocks[2].Hash)
+	defer it.Close()
+
+	var got []types.Block
+	for it.Next() {
+		got = append(got, *it.Block())
+	}
+
+	ts.Assert().NoError(it.Err())
+	ts.Assert().Equal([]types.Block{sampleBlocks[1], sampleBlocks[0]}, got)
+
+	it = ts.keeper.AncestorsOf("")
+	defer it.Close()
+
+	ts.Assert().False(it.Next())
+	ts.Assert().ErrorIs(it.Err(), errors.ErrNotFound)
+}
+
+func (ts *BaseBlockKeeperTestSuite) TestAncestorsOfStopsOnConcurrentEviction() {
+	keeper := NewBaseBlockKeeper(3)
+	for _, b := range sampleBlocks {
+		ts.Require().NoError(keeper.Add(b))
+	}
+
+	it := keeper.AncestorsOf(sampleBlocks[2].Hash)
+	defer it.Close()
+
+	// Fill the keeper past capacity so Add evicts sampleBlocks[0], the oldest
+	// ancestor the iterator hasn't reached yet.
+	ts.Require().NoError(keeper.Add(types.Block{
+		Number:     big.NewInt(35338115),
+		Hash:       "0x29736b68f357f61d0ae3d8b78762949a0b2da1d99b0f4a9be56edd28e7839643",
+		ParentHash: sampleBlocks[2].Hash,
+	}))
+
+	var got []types.Block
+	for it.Next() {
+		got = append(got, *it.Block())
+	}
+
+	ts.Assert().Equal([]types.Block{sampleBlocks[1]}, got)
+}
+
+func TestBaseBlockKeeperWithBloomExists(t *testing.T) {
+	keeper := NewBaseBlockKeeperWithBloom(4, 0, 0)
+
+	for _, b := range sampleBlocks {
+		require.NoError(t, keeper.Add(b))
+	}
+
+	for _, b := range sampleBlocks {
+		exists, err := keeper.Exists(b.Hash)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	}
+
+	exists, err := keeper.Exists("0xdoesnotexist")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestBaseBlockKeeperWithBloomEviction(t *testing.T) {
+	keeper := NewBaseBlockKeeperWithBloom(2, 0, 0)
+
+	require.NoError(t, keeper.Add(sampleBlocks[0]))
+	require.NoError(t, keeper.Add(sampleBlocks[1]))
+
+	// Exceeding cap evicts sampleBlocks[0], which must decrement its cells so
+	// the bloom filter doesn't keep reporting it as possibly present forever.
+	require.NoError(t, keeper.Add(sampleBlocks[2]))
+
+	assert.False(t, keeper.bloom.MayContain(sampleBlocks[0].Hash))
+
+	for _, b := range []types.Block{sampleBlocks[1], sampleBlocks[2]} {
+		exists, err := keeper.Exists(b.Hash)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	}
+
+	exists, err := keeper.Exists(sampleBlocks[0].Hash)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
 func TestBaseBlockKeeperTestSuite(t *testing.T) {
 	suite.Run(t, new(BaseBlockKeeperTestSuite))
 }
@@ -0,0 +1,104 @@
+package block
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// countingBloomFilter is a fixed-size counting bloom filter used as a fast-path
+// reject for "have we seen this hash before" checks. Unlike a plain bloom filter
+// it supports Remove, so cells can be decremented when an entry is evicted from
+// a bounded keeper, keeping the filter accurate for long-running processes.
+type countingBloomFilter struct {
+	cells []uint8
+	m     uint
+	k     uint
+}
+
+// newCountingBloomFilter returns a counting bloom filter with m cells and k hash
+// functions. m and k are clamped to at least 1.
+func newCountingBloomFilter(m, k uint) *countingBloomFilter {
+	if m == 0 {
+		m = 1
+	}
+
+	if k == 0 {
+		k = 1
+	}
+
+	return &countingBloomFilter{cells: make([]uint8, m), m: m, k: k}
+}
+
+// bloomSizeFor returns (m, k) sized so that the false-positive rate stays under
+// ~1% for the given number of entries, using the standard optimal-bloom-filter
+// formulas: m = -n*ln(p)/ln(2)^2, k = (m/n)*ln(2).
+func bloomSizeFor(n uint) (m, k uint) {
+	if n == 0 {
+		n = 1
+	}
+
+	const falsePositiveRate = 0.01
+
+	nf := float64(n)
+	mf := math.Ceil(-nf * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	kf := math.Round((mf / nf) * math.Ln2)
+
+	if kf < 1 {
+		kf = 1
+	}
+
+	return uint(mf), uint(kf)
+}
+
+// indices returns the k cell indices for key, derived from two independent FNV
+// hashes combined via double hashing (Kirsch-Mitzenmacher).
+func (f *countingBloomFilter) indices(key string) []uint {
+	h1 := fnv.New64a()
+	h1.Write([]byte(key)) //nolint:errcheck
+
+	h2 := fnv.New64a()
+	h2.Write([]byte(key))  //nolint:errcheck
+	h2.Write([]byte{0xff}) //nolint:errcheck
+
+	a, b := h1.Sum64(), h2.Sum64()
+
+	idx := make([]uint, f.k)
+	for i := uint(0); i < f.k; i++ {
+		idx[i] = uint(a+uint64(i)*b) % f.m
+	}
+
+	return idx
+}
+
+// Add increments the cells for key.
+func (f *countingBloomFilter) Add(key string) {
+	for _, i := range f.indices(key) {
+		if f.cells[i] < math.MaxUint8 {
+			f.cells[i]++
+		}
+	}
+}
+
+// Remove decrements the cells for key. Key must previously have been Added;
+// removing a key that was never added may cause false negatives for other keys
+// sharing its cells.
+func (f *countingBloomFilter) Remove(key string) {
+	for _, i := range f.indices(key) {
+		if f.cells[i] > 0 {
+			f.cells[i]--
+		}
+	}
+}
+
+// MayContain reports whether key might have been added. A false return is
+// authoritative; a true return must still be confirmed against the source of
+// truth.
+func (f *countingBloomFilter) MayContain(key string) bool {
+	for _, i := range f.indices(key) {
+		if f.cells[i] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
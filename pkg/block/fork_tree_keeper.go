@@ -0,0 +1,350 @@
+package block
+
+import (
+	"sync"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+)
+
+// node is a single block tracked by ForkTreeKeeper, linked to its parent and children
+// so that competing branches can coexist until one is pruned.
+type node struct {
+	block    types.Block
+	parent   *node
+	children []*node
+	depth    uint64
+}
+
+// ReorgInfo describes a canonical-head change: the branches being reverted and the
+// branch being applied, rooted at their common ancestor.
+type ReorgInfo struct {
+	CommonAncestor string
+	Reverted       []types.Block
+	Applied        []types.Block
+}
+
+// ForkTreeKeeper tracks every known block by hash, keeping parent-child links so it
+// can hold multiple competing branches at once and pick a canonical tip by depth,
+// unlike BaseBlockKeeper which only ever tracks a single chain.
+type ForkTreeKeeper struct {
+	nodes map[string]*node
+	tips  map[string]*node
+	head  *node
+	depth uint64
+
+	finalized   *node
+	finalizedCh []chan types.Block
+
+	mu sync.Mutex
+}
+
+// NewForkTreeKeeper returns a ForkTreeKeeper that prunes branches whose tip is more
+// than depth blocks behind the current head.
+func NewForkTreeKeeper(depth uint64) *ForkTreeKeeper {
+	return &ForkTreeKeeper{
+		nodes: make(map[string]*node),
+		tips:  make(map[string]*node),
+		depth: depth,
+	}
+}
+
+// Init resets the keeper to an empty state.
+func (k *ForkTreeKeeper) Init() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.nodes = make(map[string]*node)
+	k.tips = make(map[string]*node)
+	k.head = nil
+	k.finalized = nil
+
+	return nil
+}
+
+// Len returns the number of blocks currently tracked across all branches.
+func (k *ForkTreeKeeper) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return len(k.nodes)
+}
+
+// Cap returns the prune depth, i.e. how far behind the head a tip may fall before
+// its branch is dropped.
+func (k *ForkTreeKeeper) Cap() int {
+	return int(k.depth)
+}
+
+// Add inserts block into the tree and re-evaluates the canonical head.
+func (k *ForkTreeKeeper) Add(block types.Block) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.nodes[block.Hash]; ok {
+		return errors.ErrAlreadyExists
+	}
+
+	parent := k.nodes[block.ParentHash]
+
+	n := &node{block: block, parent: parent}
+	if parent != nil {
+		n.depth = parent.depth + 1
+		parent.children = append(parent.children, n)
+		delete(k.tips, parent.block.Hash)
+	}
+
+	k.nodes[block.Hash] = n
+	k.tips[block.Hash] = n
+
+	if k.head == nil || n.depth > k.head.depth {
+		k.head = n
+	}
+
+	k.prune()
+
+	return nil
+}
+
+// prune drops any branch whose tip is more than depth behind the current head.
+// Must be called with mu held.
+func (k *ForkTreeKeeper) prune() {
+	if k.head == nil || k.depth == 0 {
+		return
+	}
+
+	for hash, tip := range k.tips {
+		if tip == k.head || k.head.depth-tip.depth <= k.depth {
+			continue
+		}
+
+		for n := tip; n != nil && len(n.children) == 0; n = n.parent {
+			delete(k.nodes, n.block.Hash)
+			delete(k.tips, n.block.Hash)
+
+			if n.parent != nil {
+				n.parent.children = removeChild(n.parent.children, n)
+			}
+		}
+
+		delete(k.tips, hash)
+	}
+}
+
+func removeChild(children []*node, target *node) []*node {
+	for i, c := range children {
+		if c == target {
+			return append(children[:i], children[i+1:]...)
+		}
+	}
+
+	return children
+}
+
+// Exists reports whether a block with the given hash is known to the keeper.
+func (k *ForkTreeKeeper) Exists(hash string) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	_, ok := k.nodes[hash]
+
+	return ok, nil
+}
+
+// Get returns the block with the given hash.
+func (k *ForkTreeKeeper) Get(hash string) (types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	n, ok := k.nodes[hash]
+	if !ok {
+		return types.Block{}, errors.ErrNotFound
+	}
+
+	return n.block, nil
+}
+
+// Head returns the block of the current canonical tip.
+func (k *ForkTreeKeeper) Head() (types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.head == nil {
+		return types.Block{}, errors.ErrNotFound
+	}
+
+	return k.head.block, nil
+}
+
+// IsReorg reports whether block does not extend the current head, i.e. whether it
+// belongs to a branch other than the canonical one. It returns only that boolean:
+// the common ancestor and the reverted and applied blocks are not returned here,
+// and are computed by Reorg once the block has been added. It returns
+// ErrReorgBelowFinalized if the switch would revert a finalized block.
+func (k *ForkTreeKeeper) IsReorg(block types.Block) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.head == nil {
+		return false, nil
+	}
+
+	if block.ParentHash == k.head.block.Hash {
+		return false, nil
+	}
+
+	newParent, ok := k.nodes[block.ParentHash]
+	if !ok {
+		// The parent is unknown, e.g. a deeply-pruned branch or a lagging RPC
+		// source: we cannot compute a common ancestor, so we cannot prove this
+		// doesn't revert a finalized block. Be conservative and refuse it
+		// rather than bypassing the finality guard below.
+		if k.finalized != nil {
+			return false, errors.ErrReorgBelowFinalized
+		}
+
+		return true, nil
+	}
+
+	ancestor, reverted, applied := commonAncestor(k.head, newParent)
+	isReorg := len(reverted) > 0 || len(applied) > 0
+
+	if isReorg && k.finalized != nil && ancestor != k.finalized.block.Hash {
+		// No common ancestor means every block on the head's chain, the finalized
+		// one included, would be reverted.
+		if a, ok := k.nodes[ancestor]; !ok || a.depth < k.finalized.depth {
+			return false, errors.ErrReorgBelowFinalized
+		}
+	}
+
+	return isReorg, nil
+}
+
+// MarkFinalized designates the block with the given hash as finalized and
+// notifies any subscribers.
+func (k *ForkTreeKeeper) MarkFinalized(hash string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	n, ok := k.nodes[hash]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	k.finalized = n
+
+	for _, ch := range k.finalizedCh {
+		select {
+		case ch <- n.block:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Finalized returns the most recently finalized block.
+func (k *ForkTreeKeeper) Finalized() (types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.finalized == nil {
+		return types.Block{}, errors.ErrNotFound
+	}
+
+	return k.finalized.block, nil
+}
+
+// SubscribeFinalized returns a channel that receives every block marked
+// finalized from this point on.
+func (k *ForkTreeKeeper) SubscribeFinalized() <-chan types.Block {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	ch := make(chan types.Block, 1)
+	k.finalizedCh = append(k.finalizedCh, ch)
+
+	return ch
+}
+
+// Reorg computes the ReorgInfo for switching the canonical head from its current
+// node to newTip, by walking both branches back to their common ancestor.
+func (k *ForkTreeKeeper) Reorg(newTipHash string) (ReorgInfo, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	newTip, ok := k.nodes[newTipHash]
+	if !ok {
+		return ReorgInfo{}, errors.ErrNotFound
+	}
+
+	if k.head == nil {
+		return ReorgInfo{}, errors.ErrNotFound
+	}
+
+	ancestor, reverted, applied := commonAncestor(k.head, newTip)
+
+	return ReorgInfo{
+		CommonAncestor: ancestor,
+		Reverted:       reverted,
+		Applied:        applied,
+	}, nil
+}
+
+// commonAncestor walks from from and to back to their lowest common ancestor,
+// returning its hash plus the blocks to revert (from's branch, new-to-old order
+// excluding the ancestor) and apply (to's branch, ancestor-to-tip order).
+func commonAncestor(from, to *node) (string, []types.Block, []types.Block) {
+	fromChain := map[string]*node{}
+
+	for n := from; n != nil; n = n.parent {
+		fromChain[n.block.Hash] = n
+	}
+
+	var toPath []*node
+
+	n := to
+	for n != nil {
+		if _, ok := fromChain[n.block.Hash]; ok {
+			break
+		}
+
+		toPath = append(toPath, n)
+		n = n.parent
+	}
+
+	var ancestorHash string
+	if n != nil {
+		ancestorHash = n.block.Hash
+	}
+
+	var reverted []types.Block
+	for r := from; r != nil && r.block.Hash != ancestorHash; r = r.parent {
+		reverted = append(reverted, r.block)
+	}
+
+	applied := make([]types.Block, len(toPath))
+	for i, a := range toPath {
+		applied[len(toPath)-1-i] = a.block
+	}
+
+	return ancestorHash, reverted, applied
+}
+
+// GetRecentBlocks returns up to n of the most recently added blocks on the
+// canonical chain, newest first.
+func (k *ForkTreeKeeper) GetRecentBlocks(n int) ([]types.Block, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if n <= 0 {
+		return nil, errors.ErrInvalidArgument
+	}
+
+	blocks := make([]types.Block, 0, n)
+	for cur := k.head; cur != nil && len(blocks) < n; cur = cur.parent {
+		blocks = append(blocks, cur.block)
+	}
+
+	return blocks, nil
+}
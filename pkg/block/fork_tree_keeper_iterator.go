@@ -0,0 +1,63 @@
+package block
+
+import (
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+)
+
+// forkTreeAncestorIterator walks a ForkTreeKeeper's parent links from a starting
+// node towards the root, without materialising the path as a slice.
+type forkTreeAncestorIterator struct {
+	k     *ForkTreeKeeper
+	cur   *node
+	block types.Block
+	err   error
+}
+
+// AncestorsOf returns an iterator over the ancestors of the block with the given
+// hash, walking parent links towards the root of the tree.
+func (k *ForkTreeKeeper) AncestorsOf(hash string) types.BlockIterator {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	n, ok := k.nodes[hash]
+	if !ok {
+		return &forkTreeAncestorIterator{err: errors.ErrNotFound}
+	}
+
+	return &forkTreeAncestorIterator{k: k, cur: n}
+}
+
+// Next advances the iterator and reports whether a block is available.
+func (it *forkTreeAncestorIterator) Next() bool {
+	if it.err != nil || it.k == nil {
+		return false
+	}
+
+	it.k.mu.Lock()
+	defer it.k.mu.Unlock()
+
+	if it.cur == nil || it.cur.parent == nil {
+		return false
+	}
+
+	it.cur = it.cur.parent
+	it.block = it.cur.block
+
+	return true
+}
+
+// Block returns the block at the iterator's current position.
+func (it *forkTreeAncestorIterator) Block() *types.Block {
+	return &it.block
+}
+
+// Err returns the first error encountered by the iterator, if any.
+func (it *forkTreeAncestorIterator) Err() error {
+	return it.err
+}
+
+// Close is a no-op: the iterator holds no resources beyond the keeper itself.
+func (it *forkTreeAncestorIterator) Close() error {
+	return nil
+}
@@ -0,0 +1,150 @@
+package block
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+	"github.com/stretchr/testify/suite"
+)
+
+type ForkTreeKeeperTestSuite struct {
+	suite.Suite
+
+	keeper *ForkTreeKeeper
+}
+
+func (ts *ForkTreeKeeperTestSuite) SetupTest() {
+	ts.keeper = NewForkTreeKeeper(4)
+
+	// Check ForkTreeKeeper implemented Keeper interface.
+	var _ Keeper = ts.keeper
+
+	for _, b := range sampleBlocks {
+		err := ts.keeper.Add(b)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestAdd() {
+	n := ts.keeper.Len()
+	ts.Assert().Equal(3, n)
+
+	err := ts.keeper.Add(sampleBlocks[0])
+	ts.Assert().ErrorIs(err, errors.ErrAlreadyExists)
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestHead() {
+	block, err := ts.keeper.Head()
+	if ts.Assert().NoError(err) {
+		ts.Assert().Equal(sampleBlocks[len(sampleBlocks)-1], block)
+	}
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestIsReorgWithCompetingBranch() {
+	competing := types.Block{
+		Number:     big.NewInt(35338114),
+		Hash:       "0x29736b68f357f61d0ae3d8b78762949a0b2da1d99b0f4a9be56edd28e7839643",
+		ParentHash: sampleBlocks[1].Hash,
+	}
+
+	isReorg, err := ts.keeper.IsReorg(competing)
+	if ts.Assert().NoError(err) {
+		ts.Assert().True(isReorg)
+	}
+
+	ts.Require().NoError(ts.keeper.Add(competing))
+
+	info, err := ts.keeper.Reorg(competing.Hash)
+	if ts.Assert().NoError(err) {
+		ts.Assert().Equal(sampleBlocks[1].Hash, info.CommonAncestor)
+		ts.Assert().Equal([]types.Block{sampleBlocks[2]}, info.Reverted)
+		ts.Assert().Equal([]types.Block{competing}, info.Applied)
+	}
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestIsReorgExtendingHead() {
+	block := types.Block{
+		Number:     big.NewInt(35338115),
+		Hash:       "0x29736b68f357f61d0ae3d8b78762949a0b2da1d99b0f4a9be56edd28e7839643",
+		ParentHash: sampleBlocks[2].Hash,
+	}
+
+	isReorg, err := ts.keeper.IsReorg(block)
+	if ts.Assert().NoError(err) {
+		ts.Assert().False(isReorg)
+	}
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestMarkFinalized() {
+	sub := ts.keeper.SubscribeFinalized()
+
+	err := ts.keeper.MarkFinalized(sampleBlocks[1].Hash)
+	if ts.Assert().NoError(err) {
+		block, err := ts.keeper.Finalized()
+		if ts.Assert().NoError(err) {
+			ts.Assert().Equal(sampleBlocks[1], block)
+		}
+
+		ts.Assert().Equal(sampleBlocks[1], <-sub)
+	}
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestIsReorgBelowFinalized() {
+	ts.Require().NoError(ts.keeper.MarkFinalized(sampleBlocks[2].Hash))
+
+	competing := types.Block{
+		Number:     big.NewInt(35338114),
+		Hash:       "0x29736b68f357f61d0ae3d8b78762949a0b2da1d99b0f4a9be56edd28e7839643",
+		ParentHash: sampleBlocks[0].Hash,
+	}
+
+	_, err := ts.keeper.IsReorg(competing)
+	ts.Assert().ErrorIs(err, errors.ErrReorgBelowFinalized)
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestIsReorgBelowFinalizedWithUnknownParent() {
+	ts.Require().NoError(ts.keeper.MarkFinalized(sampleBlocks[2].Hash))
+
+	orphan := types.Block{
+		Number:     big.NewInt(35338116),
+		Hash:       "0x4e2c7c8dbf7a8f3a9c5a5d5b2b1e6a0f9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3",
+		ParentHash: "0xunknownparenthashneverseenbythiskeeper000000000000000000000000",
+	}
+
+	_, err := ts.keeper.IsReorg(orphan)
+	ts.Assert().ErrorIs(err, errors.ErrReorgBelowFinalized)
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestIsReorgWithUnknownParentAndNoFinalized() {
+	orphan := types.Block{
+		Number:     big.NewInt(35338116),
+		Hash:       "0x4e2c7c8dbf7a8f3a9c5a5d5b2b1e6a0f9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3",
+		ParentHash: "0xunknownparenthashneverseenbythiskeeper000000000000000000000000",
+	}
+
+	isReorg, err := ts.keeper.IsReorg(orphan)
+	if ts.Assert().NoError(err) {
+		ts.Assert().True(isReorg)
+	}
+}
+
+func (ts *ForkTreeKeeperTestSuite) TestAncestorsOf() {
+	it := ts.keeper.AncestorsOf(sampleBlocks[2].Hash)
+	defer it.Close()
+
+	var got []types.Block
+	for it.Next() {
+		got = append(got, *it.Block())
+	}
+
+	ts.Assert().NoError(it.Err())
+	ts.Assert().Equal([]types.Block{sampleBlocks[1], sampleBlocks[0]}, got)
+}
+
+func TestForkTreeKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(ForkTreeKeeperTestSuite))
+}
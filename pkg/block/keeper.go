@@ -0,0 +1,50 @@
+// Package block tracks recently observed blocks and detects chain reorganisations.
+package block
+
+import "github.com/KyberNetwork/evmlistener/pkg/types"
+
+// Keeper tracks the most recently observed blocks and detects reorgs against them.
+type Keeper interface {
+	// Init (re-)initialises the keeper's internal state.
+	Init() error
+
+	// Add inserts a new block into the keeper.
+	Add(block types.Block) error
+
+	// Len returns the number of blocks currently held.
+	Len() int
+
+	// Cap returns the keeper's size bound: the maximum number of blocks for
+	// BaseBlockKeeper, the prune depth for ForkTreeKeeper.
+	Cap() int
+
+	// Exists reports whether a block with the given hash is known to the keeper.
+	Exists(hash string) (bool, error)
+
+	// Get returns the block with the given hash.
+	Get(hash string) (types.Block, error)
+
+	// Head returns the current canonical tip.
+	Head() (types.Block, error)
+
+	// IsReorg reports whether adding block would cause a reorg of the canonical chain.
+	IsReorg(block types.Block) (bool, error)
+
+	// GetRecentBlocks returns up to n of the most recently added blocks, newest first.
+	GetRecentBlocks(n int) ([]types.Block, error)
+
+	// MarkFinalized designates the block with the given hash, and everything
+	// behind it, as finalized and therefore irreversible.
+	MarkFinalized(hash string) error
+
+	// Finalized returns the most recently finalized block.
+	Finalized() (types.Block, error)
+
+	// SubscribeFinalized returns a channel that receives every block marked
+	// finalized from this point on.
+	SubscribeFinalized() <-chan types.Block
+
+	// AncestorsOf returns an iterator over the ancestors of the block with the
+	// given hash, walking parent hashes from newest to oldest.
+	AncestorsOf(hash string) types.BlockIterator
+}
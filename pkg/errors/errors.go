@@ -0,0 +1,12 @@
+// Package errors defines sentinel errors shared across the listener and block packages.
+package errors
+
+import "errors"
+
+// Sentinel errors returned by the queue and block keepers.
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrAlreadyExists       = errors.New("already exists")
+	ErrInvalidArgument     = errors.New("invalid argument")
+	ErrReorgBelowFinalized = errors.New("reorg would rewind past the finalized block")
+)
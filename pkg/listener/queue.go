@@ -15,8 +15,9 @@ type Queue struct {
 	maxSize int
 	size    int
 
-	blockNumber uint64
-	mu          sync.Mutex
+	blockNumber     uint64
+	finalizedNumber uint64
+	mu              sync.Mutex
 }
 
 // NewQueue instantiates a new empty queue with the specified size of maximum number of elements that it can hold.
@@ -219,3 +220,45 @@ func (q *Queue) SetBlockNumber(number uint64) {
 
 	q.blockNumber = number
 }
+
+// FinalizedBlockNumber returns the highest block number known to be finalized.
+func (q *Queue) FinalizedBlockNumber() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.finalizedNumber
+}
+
+// SetFinalizedBlockNumber records number as the highest finalized block number.
+func (q *Queue) SetFinalizedBlockNumber(number uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.finalizedNumber = number
+}
+
+// DequeueUntil drains and returns every queued block at or below number, in
+// ascending order. It stops at the first gap, since later blocks cannot be
+// finalized before the ones they build on have been dequeued.
+func (q *Queue) DequeueUntil(number uint64) []*types.Block {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var values []*types.Block
+
+	for !q.empty() && q.blockNumber <= number {
+		// Leave the gap slot in place so its block can still be inserted later.
+		if q.values[q.start] == nil {
+			break
+		}
+
+		value, ok := q.dequeue()
+		if !ok {
+			break
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
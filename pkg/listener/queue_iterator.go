@@ -0,0 +1,139 @@
+package listener
+
+import "github.com/KyberNetwork/evmlistener/pkg/types"
+
+// queueIterator walks a Queue by block number rather than by a slice offset
+// fixed at construction time. A logical offset captured once would land on the
+// wrong physical slot after a concurrent Dequeue/Insert rotates the ring (q.start
+// and q.blockNumber both shift), so every step re-derives its slot from the
+// queue's current q.start/q.blockNumber instead.
+type queueIterator struct {
+	q         *Queue
+	next      uint64
+	bound     uint64
+	reverse   bool
+	exhausted bool
+	closed    bool
+	block     *types.Block
+}
+
+func newQueueIterator(q *Queue, from, bound uint64, reverse bool) *queueIterator {
+	return &queueIterator{q: q, next: from, bound: bound, reverse: reverse}
+}
+
+// advance moves the iterator to the next candidate block number, marking it
+// exhausted instead of underflowing when a reverse scan reaches zero.
+func (it *queueIterator) advance() {
+	if it.reverse {
+		if it.next == 0 {
+			it.exhausted = true
+
+			return
+		}
+
+		it.next--
+
+		return
+	}
+
+	it.next++
+}
+
+// Next advances the iterator and reports whether a block is available. It
+// re-validates its position against the queue's live start/blockNumber on every
+// call, so a concurrent rotation of the ring causes it to skip past evicted
+// numbers (or stop) instead of silently returning the block that now happens to
+// occupy the same slot.
+func (it *queueIterator) Next() bool {
+	if it.closed || it.exhausted {
+		it.block = nil
+
+		return false
+	}
+
+	it.q.mu.Lock()
+	defer it.q.mu.Unlock()
+
+	for !it.exhausted {
+		if it.reverse && it.next < it.bound {
+			it.exhausted = true
+
+			break
+		}
+
+		if !it.reverse && it.next > it.bound {
+			it.exhausted = true
+
+			break
+		}
+
+		num := it.next
+		it.advance()
+
+		if num < it.q.blockNumber {
+			// num has been permanently evicted. Going forward there may still be
+			// later numbers available, so keep scanning; going backward every
+			// smaller number is evicted too, since blockNumber only increases.
+			if it.reverse {
+				it.exhausted = true
+
+				break
+			}
+
+			continue
+		}
+
+		idx := int(num - it.q.blockNumber)
+		if idx >= it.q.maxSize {
+			// num hasn't arrived yet relative to the current window.
+			it.exhausted = true
+
+			break
+		}
+
+		if v := it.q.values[(it.q.start+idx)%it.q.maxSize]; v != nil {
+			it.block = v
+
+			return true
+		}
+	}
+
+	it.block = nil
+
+	return false
+}
+
+// Block returns the block at the iterator's current position.
+func (it *queueIterator) Block() *types.Block {
+	return it.block
+}
+
+// Err always returns nil: a queueIterator cannot fail independently of the queue.
+func (it *queueIterator) Err() error {
+	return nil
+}
+
+// Close marks the iterator as exhausted.
+func (it *queueIterator) Close() error {
+	it.closed = true
+
+	return nil
+}
+
+// Iterator returns a BlockIterator over the blocks with number in [from, to],
+// in ascending order, without copying the buffer. It stays correct even if
+// blocks are dequeued or inserted while the iterator is in use.
+func (q *Queue) Iterator(from, to uint64) types.BlockIterator {
+	return newQueueIterator(q, from, to, false)
+}
+
+// ReverseIterator returns a BlockIterator over every currently queued block,
+// newest first, without copying the buffer. It stays correct even if blocks are
+// dequeued or inserted while the iterator is in use.
+func (q *Queue) ReverseIterator() types.BlockIterator {
+	q.mu.Lock()
+	newest := q.blockNumber + uint64(q.maxSize) - 1
+	q.mu.Unlock()
+
+	return newQueueIterator(q, newest, 0, true)
+}
@@ -0,0 +1,53 @@
+package listener
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func blockAt(n uint64) *types.Block {
+	return &types.Block{Number: new(big.Int).SetUint64(n), Hash: fmt.Sprintf("0x%d", n)}
+}
+
+func TestQueueIteratorSurvivesConcurrentDequeue(t *testing.T) {
+	q := NewQueue(5)
+	for n := uint64(10); n <= 14; n++ {
+		q.Insert(blockAt(n))
+	}
+
+	it := q.Iterator(11, 13)
+	defer it.Close()
+
+	_, ok := q.Dequeue()
+	require.True(t, ok)
+
+	var got []uint64
+	for it.Next() {
+		got = append(got, it.Block().Number.Uint64())
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []uint64{11, 12, 13}, got)
+}
+
+func TestQueueReverseIterator(t *testing.T) {
+	q := NewQueue(3)
+	for n := uint64(1); n <= 3; n++ {
+		q.Insert(blockAt(n))
+	}
+
+	it := q.ReverseIterator()
+	defer it.Close()
+
+	var got []uint64
+	for it.Next() {
+		got = append(got, it.Block().Number.Uint64())
+	}
+
+	assert.Equal(t, []uint64{3, 2, 1}, got)
+}
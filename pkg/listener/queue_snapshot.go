@@ -0,0 +1,103 @@
+package listener
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/KyberNetwork/evmlistener/pkg/types"
+)
+
+// queueSnapshotVersion is the current on-disk layout of a Queue snapshot. Bump it
+// whenever the payload shape changes so Restore can reject snapshots it no longer
+// understands instead of silently corrupting state.
+const queueSnapshotVersion byte = 1
+
+// queueSnapshotPayload is the gob-encoded body of a Queue snapshot, following the
+// version byte. Values/Present are parallel slices rather than []*types.Block so
+// nil slots round-trip without relying on gob's handling of nil pointers.
+type queueSnapshotPayload struct {
+	MaxSize         int
+	Start           int
+	Size            int
+	BlockNumber     uint64
+	FinalizedNumber uint64
+	Values          []types.Block
+	Present         []bool
+}
+
+// Snapshot encodes the queue's full state so it can be restored after a restart.
+func (q *Queue) Snapshot() ([]byte, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	payload := queueSnapshotPayload{
+		MaxSize:         q.maxSize,
+		Start:           q.start,
+		Size:            q.size,
+		BlockNumber:     q.blockNumber,
+		FinalizedNumber: q.finalizedNumber,
+		Values:          make([]types.Block, q.maxSize),
+		Present:         make([]bool, q.maxSize),
+	}
+
+	for i, v := range q.values {
+		if v != nil {
+			payload.Values[i] = *v
+			payload.Present[i] = true
+		}
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteByte(queueSnapshotVersion)
+
+	if err := gob.NewEncoder(&buf).Encode(payload); err != nil {
+		return nil, fmt.Errorf("encode queue snapshot: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Restore replaces the queue's state with the one encoded in data by Snapshot.
+// The queue's maxSize cannot change across a restore, since it cannot be changed
+// at all after construction.
+func (q *Queue) Restore(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("restore queue snapshot: empty payload")
+	}
+
+	version, body := data[0], data[1:]
+	if version != queueSnapshotVersion {
+		return fmt.Errorf("restore queue snapshot: unsupported version %d", version)
+	}
+
+	var payload queueSnapshotPayload
+	if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&payload); err != nil {
+		return fmt.Errorf("decode queue snapshot: %w", err)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if payload.MaxSize != q.maxSize {
+		return fmt.Errorf("restore queue snapshot: maxSize %d does not match queue maxSize %d",
+			payload.MaxSize, q.maxSize)
+	}
+
+	values := make([]*types.Block, q.maxSize)
+	for i, present := range payload.Present {
+		if present {
+			block := payload.Values[i]
+			values[i] = &block
+		}
+	}
+
+	q.values = values
+	q.start = payload.Start
+	q.size = payload.Size
+	q.blockNumber = payload.BlockNumber
+	q.finalizedNumber = payload.FinalizedNumber
+
+	return nil
+}
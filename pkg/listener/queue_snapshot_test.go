@@ -0,0 +1,47 @@
+package listener
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueSnapshotRestore(t *testing.T) {
+	q := NewQueue(4)
+	for n := uint64(1); n <= 3; n++ {
+		q.Insert(blockAt(n))
+	}
+	q.SetFinalizedBlockNumber(1)
+
+	data, err := q.Snapshot()
+	require.NoError(t, err)
+
+	// Mutate the queue after taking the snapshot to prove Restore overwrites
+	// this state rather than merging with it.
+	q.Insert(blockAt(4))
+	q.Insert(blockAt(5))
+	q.SetFinalizedBlockNumber(4)
+
+	restored := NewQueue(4)
+	require.NoError(t, restored.Restore(data))
+
+	assert.Equal(t, uint64(1), restored.BlockNumber())
+	assert.Equal(t, uint64(1), restored.FinalizedBlockNumber())
+
+	values := restored.Values()
+	require.Len(t, values, 3)
+	assert.Equal(t, uint64(1), values[0].Number.Uint64())
+	assert.Equal(t, uint64(3), values[2].Number.Uint64())
+}
+
+func TestQueueRestoreRejectsMismatchedMaxSize(t *testing.T) {
+	q := NewQueue(4)
+	q.Insert(blockAt(1))
+
+	data, err := q.Snapshot()
+	require.NoError(t, err)
+
+	other := NewQueue(8)
+	assert.Error(t, other.Restore(data))
+}
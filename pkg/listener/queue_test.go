@@ -0,0 +1,49 @@
+package listener
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type QueueTestSuite struct {
+	suite.Suite
+}
+
+func (ts *QueueTestSuite) TestFinalizedBlockNumber() {
+	q := NewQueue(4)
+	ts.Assert().Equal(uint64(0), q.FinalizedBlockNumber())
+
+	q.SetFinalizedBlockNumber(42)
+	ts.Assert().Equal(uint64(42), q.FinalizedBlockNumber())
+}
+
+func (ts *QueueTestSuite) TestDequeueUntil() {
+	q := NewQueue(5)
+	for n := uint64(10); n <= 14; n++ {
+		q.Insert(blockAt(n))
+	}
+
+	values := q.DequeueUntil(12)
+	if ts.Assert().Len(values, 3) {
+		ts.Assert().Equal(uint64(10), values[0].Number.Uint64())
+		ts.Assert().Equal(uint64(11), values[1].Number.Uint64())
+		ts.Assert().Equal(uint64(12), values[2].Number.Uint64())
+	}
+
+	ts.Assert().Equal(uint64(13), q.BlockNumber())
+}
+
+func (ts *QueueTestSuite) TestDequeueUntilStopsAtGap() {
+	q := NewQueue(5)
+	q.Insert(blockAt(10))
+	q.Insert(blockAt(12)) // 11 never arrives, leaving a gap at index 1
+
+	values := q.DequeueUntil(14)
+	ts.Assert().Len(values, 1)
+	ts.Assert().Equal(uint64(10), values[0].Number.Uint64())
+}
+
+func TestQueueTestSuite(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
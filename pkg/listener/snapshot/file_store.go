@@ -0,0 +1,51 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+)
+
+// FileStore saves snapshots as files in a directory on the local filesystem,
+// one file per key.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore that saves snapshots under dir, creating it
+// if it does not already exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create snapshot dir: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, key)
+}
+
+// Save persists data under key, overwriting any previous value.
+func (s *FileStore) Save(_ context.Context, key string, data []byte) error {
+	if err := os.WriteFile(s.path(key), data, 0o644); err != nil {
+		return fmt.Errorf("write snapshot file: %w", err)
+	}
+
+	return nil
+}
+
+// Load returns the data previously saved under key.
+func (s *FileStore) Load(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, errors.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("read snapshot file: %w", err)
+	}
+
+	return data, nil
+}
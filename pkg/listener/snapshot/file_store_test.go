@@ -0,0 +1,33 @@
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStoreSaveLoad(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "snapshots"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	_, err = store.Load(ctx, "queue")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	require.NoError(t, store.Save(ctx, "queue", []byte("snapshot-v1")))
+
+	data, err := store.Load(ctx, "queue")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("snapshot-v1"), data)
+
+	require.NoError(t, store.Save(ctx, "queue", []byte("snapshot-v2")))
+
+	data, err = store.Load(ctx, "queue")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("snapshot-v2"), data)
+}
@@ -0,0 +1,47 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+)
+
+// RedisStore saves snapshots as string values in Redis, one key per snapshot.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore returns a RedisStore that saves snapshots through client,
+// namespacing keys under prefix.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) key(key string) string {
+	return s.prefix + key
+}
+
+// Save persists data under key, overwriting any previous value.
+func (s *RedisStore) Save(ctx context.Context, key string, data []byte) error {
+	if err := s.client.Set(ctx, s.key(key), data, 0).Err(); err != nil {
+		return fmt.Errorf("save snapshot to redis: %w", err)
+	}
+
+	return nil
+}
+
+// Load returns the data previously saved under key.
+func (s *RedisStore) Load(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if err == redis.Nil {
+		return nil, errors.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("load snapshot from redis: %w", err)
+	}
+
+	return data, nil
+}
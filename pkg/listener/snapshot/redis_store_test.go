@@ -0,0 +1,37 @@
+package snapshot
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KyberNetwork/evmlistener/pkg/errors"
+)
+
+// TestRedisStoreSaveLoad requires a reachable Redis instance and is skipped
+// unless REDIS_ADDR is set, e.g. REDIS_ADDR=localhost:6379.
+func TestRedisStoreSaveLoad(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_ADDR not set, skipping redis-backed snapshot store test")
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	defer client.Close()
+
+	store := NewRedisStore(client, "evmlistener-test:")
+	ctx := context.Background()
+
+	_, err := store.Load(ctx, "queue")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	require.NoError(t, store.Save(ctx, "queue", []byte("snapshot-v1")))
+
+	data, err := store.Load(ctx, "queue")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("snapshot-v1"), data)
+}
@@ -0,0 +1,16 @@
+// Package snapshot provides pluggable storage backends for persisting and
+// restoring listener snapshots (see Queue.Snapshot / BaseBlockKeeper.Snapshot)
+// across process restarts.
+package snapshot
+
+import "context"
+
+// Store persists and retrieves snapshot payloads by key.
+type Store interface {
+	// Save persists data under key, overwriting any previous value.
+	Save(ctx context.Context, key string, data []byte) error
+
+	// Load returns the data previously saved under key. It returns
+	// errors.ErrNotFound if no snapshot has been saved under that key yet.
+	Load(ctx context.Context, key string) ([]byte, error)
+}
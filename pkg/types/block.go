@@ -0,0 +1,11 @@
+package types
+
+import "math/big"
+
+// Block represents a minimal view of an on-chain block as observed by the listener.
+type Block struct {
+	Number      *big.Int
+	Hash        string
+	ParentHash  string
+	ReorgedHash string
+}
@@ -0,0 +1,19 @@
+package types
+
+// BlockIterator lazily walks a sequence of blocks without materialising them all
+// into a slice upfront. Callers must call Next before the first Block and should
+// Close the iterator once done with it.
+type BlockIterator interface {
+	// Next advances the iterator and reports whether a block is available.
+	Next() bool
+
+	// Block returns the block at the iterator's current position. It is only
+	// valid after a call to Next that returned true.
+	Block() *Block
+
+	// Err returns the first error encountered by the iterator, if any.
+	Err() error
+
+	// Close releases any resources held by the iterator.
+	Close() error
+}